@@ -0,0 +1,286 @@
+package tea
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ComposeCancelMsg is sent when a compose sequence is abandoned, either
+// because the user pressed [KeyEscape] or because the two keys following the
+// trigger don't correspond to any registered composition.
+type ComposeCancelMsg struct{}
+
+// composeKey is the pair of runes following the compose trigger: for
+// example, an apostrophe followed by e composes to "é".
+type composeKey [2]rune
+
+// defaultComposeTable mirrors a handful of the most common X11 Compose
+// entries. Users can extend or override it with [Program.RegisterCompose] or
+// by loading their own ~/.XCompose file via [WithComposeFile].
+var defaultComposeTable = map[composeKey]rune{
+	{'\'', 'e'}: 'é',
+	{'\'', 'a'}: 'á',
+	{'\'', 'o'}: 'ó',
+	{'`', 'e'}:  'è',
+	{'`', 'a'}:  'à',
+	{'~', 'n'}:  'ñ',
+	{'~', 'o'}:  'õ',
+	{'"', 'u'}:  'ü',
+	{'"', 'o'}:  'ö',
+	{'-', '-'}:  '–',
+	{'<', '<'}:  '«',
+	{'>', '>'}:  '»',
+	{'o', 'c'}:  '©',
+	{'o', 'r'}:  '®',
+}
+
+// composeState tracks where we are in a compose sequence: idle, having seen
+// the trigger, or having seen the trigger plus one rune.
+type composeState int
+
+const (
+	composeIdle composeState = iota
+	composeArmed
+	composePending
+)
+
+// composeProcessor accumulates the two keys following a compose trigger and
+// resolves them against a table of compositions.
+type composeProcessor struct {
+	trigger Key
+	table   map[composeKey]rune
+	state   composeState
+	first   rune
+}
+
+func newComposeProcessor(trigger string) *composeProcessor {
+	k := MustParseKey(trigger)
+	table := make(map[composeKey]rune, len(defaultComposeTable))
+	for k, v := range defaultComposeTable {
+		table[k] = v
+	}
+	return &composeProcessor{trigger: k, table: table}
+}
+
+// RegisterCompose adds or overrides a two-key composition, such that
+// pressing the compose trigger followed by a then b produces out.
+func (p *composeProcessor) RegisterCompose(a, b rune, out rune) {
+	p.table[composeKey{a, b}] = out
+}
+
+// feed advances the compose state machine with msg. On success it reports a
+// [KeyPressMsg] carrying the composed rune, as if the terminal had produced
+// it directly, so existing Update code that only looks for KeyPressMsg keeps
+// working; it reports the message that should be delivered to Update in
+// place of msg (nil if msg should be swallowed entirely) and whether msg was
+// consumed by the compose processor.
+func (p *composeProcessor) feed(msg KeyPressMsg) (out Msg, consumed bool) {
+	k := Key(msg)
+
+	switch p.state {
+	case composeIdle:
+		if k.Mod == p.trigger.Mod && k.Type == p.trigger.Type && k.Rune() == p.trigger.Rune() {
+			p.state = composeArmed
+			return nil, true
+		}
+		return msg, false
+
+	case composeArmed:
+		if k.Type == KeyEscape {
+			p.state = composeIdle
+			return ComposeCancelMsg{}, true
+		}
+		if k.Type != KeyRunes || len(k.Runes) != 1 {
+			p.state = composeIdle
+			return ComposeCancelMsg{}, true
+		}
+		p.first = k.Rune()
+		p.state = composePending
+		return nil, true
+
+	default: // composePending
+		p.state = composeIdle
+		if k.Type == KeyEscape {
+			return ComposeCancelMsg{}, true
+		}
+		if k.Type != KeyRunes || len(k.Runes) != 1 {
+			return ComposeCancelMsg{}, true
+		}
+		r, ok := p.table[composeKey{p.first, k.Rune()}]
+		if !ok {
+			return ComposeCancelMsg{}, true
+		}
+		return KeyPressMsg{Type: KeyRunes, Runes: []rune{r}}, true
+	}
+}
+
+// loadXCompose reads compositions from an X11 Compose file, such as
+// ~/.XCompose, adding each `<Multi_key> <a> <b> : "out"` rule it
+// understands. Rules using keysym names it doesn't recognize are skipped.
+func (p *composeProcessor) loadXCompose(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := sc.Text()
+		if i := strings.Index(text, "#"); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" || !strings.HasPrefix(text, "<Multi_key>") {
+			continue
+		}
+
+		lhs, rhs, ok := strings.Cut(text, ":")
+		if !ok {
+			continue
+		}
+
+		keysyms := parseAngleTokens(lhs)
+		if len(keysyms) != 3 {
+			// <Multi_key> plus exactly two keys is all we support here.
+			continue
+		}
+		a, aok := keysymRune(keysyms[1])
+		b, bok := keysymRune(keysyms[2])
+		if !aok || !bok {
+			continue
+		}
+
+		out := firstQuotedString(rhs)
+		if out == "" {
+			continue
+		}
+		runes := []rune(out)
+		p.table[composeKey{a, b}] = runes[0]
+	}
+	return sc.Err()
+}
+
+// parseAngleTokens extracts the contents of each <...> token in s, in order.
+func parseAngleTokens(s string) []string {
+	var out []string
+	for {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '>')
+		if end < 0 {
+			break
+		}
+		out = append(out, s[start+1:start+end])
+		s = s[start+end+1:]
+	}
+	return out
+}
+
+// firstQuotedString returns the contents of the first "..." substring in s.
+func firstQuotedString(s string) string {
+	start := strings.IndexByte(s, '"')
+	if start < 0 {
+		return ""
+	}
+	end := strings.IndexByte(s[start+1:], '"')
+	if end < 0 {
+		return ""
+	}
+	return s[start+1 : start+1+end]
+}
+
+// keysymNames maps the subset of X11 keysym names we understand to their
+// rune. Unrecognized single-character tokens fall back to their literal
+// rune value.
+var keysymNames = map[string]rune{
+	"apostrophe": '\'',
+	"grave":      '`',
+	"asciitilde": '~',
+	"quotedbl":   '"',
+	"minus":      '-',
+	"less":       '<',
+	"greater":    '>',
+	"space":      ' ',
+}
+
+func keysymRune(name string) (rune, bool) {
+	if r, ok := keysymNames[name]; ok {
+		return r, true
+	}
+	runes := []rune(name)
+	if len(runes) == 1 {
+		return runes[0], true
+	}
+	return 0, false
+}
+
+// WithComposeKey enables compose-sequence (dead-key) accumulation, using
+// trigger (parsed with the same grammar as [ParseKey], e.g. "rightalt" or
+// "menu") as the key that begins a composition. Once armed, the next two
+// rune key presses are combined according to the program's compose table and
+// delivered to Update as a single combined [KeyPressMsg] instead of two
+// separate ones. Unknown continuations, or pressing [KeyEscape] while a
+// sequence is in progress, produce a [ComposeCancelMsg] instead.
+//
+// Without this option, compose sequences are not processed and keys are
+// delivered to Update as-is.
+func WithComposeKey(trigger string) ProgramOption {
+	return func(p *Program) {
+		p.composeKey = newComposeProcessor(trigger)
+	}
+}
+
+// WithComposeFile loads additional compose entries from an X11
+// Compose-format file (as used by ~/.XCompose) and merges them into the
+// program's compose table. It implies [WithComposeKey] with the default
+// trigger if one hasn't already been set.
+func WithComposeFile(path string) ProgramOption {
+	return func(p *Program) {
+		if p.composeKey == nil {
+			p.composeKey = newComposeProcessor(defaultComposeTrigger)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			p.composeFileErr = fmt.Errorf("tea: loading compose file %s: %w", path, err)
+			return
+		}
+		defer f.Close() //nolint:errcheck
+		if err := p.composeKey.loadXCompose(f); err != nil {
+			p.composeFileErr = fmt.Errorf("tea: parsing compose file %s: %w", path, err)
+		}
+	}
+}
+
+// defaultComposeTrigger is the stroke used to arm a compose sequence when
+// none is specified, modelled on the X11 Multi_key default of right-alt.
+const defaultComposeTrigger = "rightalt"
+
+// defaultXComposeFile returns the conventional path to the user's personal
+// compose table, as read by X11 clients.
+func defaultXComposeFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".XCompose")
+}
+
+// WithDefaultXComposeFile is like [WithComposeFile], but loads the user's
+// own ~/.XCompose file (the same path X11 clients use) if it exists, and is
+// silently a no-op otherwise. Use this to pick up a user's personal compose
+// customizations without hard-coding their home directory.
+func WithDefaultXComposeFile() ProgramOption {
+	return func(p *Program) {
+		path := defaultXComposeFile()
+		if path == "" {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			return
+		}
+		WithComposeFile(path)(p)
+	}
+}
@@ -1,5 +1,10 @@
 package tea
 
+import (
+	"fmt"
+	"strings"
+)
+
 // KeyType indicates whether the key is a special key or runes. Special
 // keys are things like KeyEnter, KeyBackspace, and so on. Runes keys are just
 // regular characters like 'a', '你', 'ض', '🦄', and so on.
@@ -256,6 +261,12 @@ type Key struct {
 	// Console API.
 	baseRune rune
 
+	// scancode is the physical key position reported by hosts that know it
+	// (the Windows Console API, or Kitty's CSI-u "alternate key"
+	// reporting), used to look the key up in a [KeyLayout]. It is zero when
+	// the host didn't report one.
+	scancode uint8
+
 	// Mod is a modifier key, like ctrl, alt, and so on.
 	Mod KeyMod
 
@@ -265,6 +276,14 @@ type Key struct {
 	// This is only available with the Kitty Keyboard Protocol or the Windows
 	// Console API.
 	IsRepeat bool
+
+	// IsSynthetic indicates that IsRepeat was set by Bubble Tea's own
+	// synthetic key-repeat detection (see [WithSyntheticKeyRepeat]) rather
+	// than reported by the terminal itself. Models that care about the
+	// difference, for example to trust protocol-reported repeats more than
+	// a heuristic, can check this field; most models can ignore it and
+	// treat IsRepeat the same either way.
+	IsSynthetic bool
 }
 
 // KeyPressMsg represents a key press message.
@@ -529,3 +548,113 @@ var keyTypeString = map[KeyType]string{
 	KeyIsoLevel3Shift:   "isolevel3shift",
 	KeyIsoLevel5Shift:   "isolevel5shift",
 }
+
+// stringKeyType is the inverse of keyTypeString, built once at init time so
+// ParseKey can look up a KeyType from its textual name in O(1).
+var stringKeyType = func() map[string]KeyType {
+	m := make(map[string]KeyType, len(keyTypeString))
+	for t, s := range keyTypeString {
+		if t == KeyRunes {
+			// "runes" isn't a parseable token on its own; a KeyRunes key is
+			// represented by the rune(s) themselves.
+			continue
+		}
+		m[s] = t
+	}
+	return m
+}()
+
+// modNames holds the canonical modifier names in the exact order they must
+// appear in both Key.String and ParseKey.
+var modNames = []struct {
+	name string
+	mod  KeyMod
+}{
+	{"ctrl", ModCtrl},
+	{"alt", ModAlt},
+	{"shift", ModShift},
+	{"meta", ModMeta},
+	{"hyper", ModHyper},
+	{"super", ModSuper},
+}
+
+// ParseKey parses a string produced by [Key.String] back into a Key. It is
+// the inverse of Key.String: for any Key k returned by the input driver,
+// ParseKey(k.String()) reproduces a Key whose String method returns the same
+// string.
+//
+// The expected grammar is a sequence of modifiers in canonical order
+// ("ctrl+alt+shift+meta+hyper+super+"), each optional, followed by either a
+// single rune (e.g. "a", "你"), the literal "space", or one of the key names
+// found in the table underlying [KeyType.String] (e.g. "enter", "f13",
+// "kpenter", "medianext", "isolevel3shift"). Matching of modifier and key
+// names is case-insensitive.
+//
+// ParseKey returns an error if s is empty or contains an unrecognized
+// modifier or key token.
+func ParseKey(s string) (Key, error) {
+	if s == "" {
+		return Key{}, fmt.Errorf("tea: cannot parse empty key")
+	}
+
+	var mod KeyMod
+	rest := s
+	for {
+		i := strings.IndexByte(rest, '+')
+		if i < 0 {
+			break
+		}
+		token := strings.ToLower(rest[:i])
+		m, ok := modToken(token)
+		if !ok {
+			// Not a modifier; treat whatever remains (including the '+') as
+			// the key name itself, e.g. a literal "+" key.
+			break
+		}
+		mod |= m
+		rest = rest[i+1:]
+	}
+
+	if rest == "" {
+		return Key{}, fmt.Errorf("tea: missing key name in %q", s)
+	}
+
+	lower := strings.ToLower(rest)
+	if lower == "space" {
+		return Key{Type: KeySpace, Runes: []rune{' '}, Mod: mod}, nil
+	}
+	// Key names are matched case-insensitively, but a name match only wins
+	// when the lowercased token is actually one of our names: an uppercase
+	// rune like "A" must still fall through to the single-rune case below,
+	// since it lowercases to "a", which isn't a key name either.
+	if t, ok := stringKeyType[lower]; ok {
+		return Key{Type: t, Mod: mod}, nil
+	}
+	r := []rune(rest)
+	if len(r) == 1 {
+		return Key{Type: KeyRunes, Runes: r, Mod: mod}, nil
+	}
+	return Key{}, fmt.Errorf("tea: unknown key %q", rest)
+}
+
+// modToken reports whether token is a valid, lowercase modifier name and
+// returns the corresponding KeyMod bit.
+func modToken(token string) (KeyMod, bool) {
+	for _, m := range modNames {
+		if m.name == token {
+			return m.mod, true
+		}
+	}
+	return 0, false
+}
+
+// MustParseKey is like [ParseKey] but panics if s cannot be parsed. It is
+// intended for use with trusted, static strings such as those found in
+// keymap definitions within program source.
+func MustParseKey(s string) Key {
+	k, err := ParseKey(s)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
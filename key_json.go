@@ -0,0 +1,146 @@
+package tea
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonKey is the on-the-wire shape shared by Key, KeyPressMsg, and
+// KeyReleaseMsg. Type is only set when marshalling a message ("press" or
+// "release"); a bare Key omits it.
+type jsonKey struct {
+	Key       string   `json:"key"`
+	Type      string   `json:"type,omitempty"`
+	Repeat    bool     `json:"repeat,omitempty"`
+	Synthetic bool     `json:"synthetic,omitempty"`
+	Runes     string   `json:"runes,omitempty"`
+	Base      string   `json:"base,omitempty"`
+	Alt       string   `json:"alt,omitempty"`
+	Mod       []string `json:"mod,omitempty"`
+}
+
+// toJSON converts k to its wire representation, using msgType ("" for a
+// bare Key, "press", or "release") as the type discriminator.
+func (k Key) toJSON(msgType string) jsonKey {
+	aux := jsonKey{
+		Key:       k.String(),
+		Type:      msgType,
+		Repeat:    k.IsRepeat,
+		Synthetic: k.IsSynthetic,
+		Mod:       modStrings(k.Mod),
+	}
+	if k.Type == KeyRunes {
+		aux.Runes = string(k.Runes)
+	}
+	if k.baseRune != 0 {
+		aux.Base = string(k.baseRune)
+	}
+	if k.altRune != 0 {
+		aux.Alt = string(k.altRune)
+	}
+	return aux
+}
+
+// fromJSON populates k from a decoded wire representation. The textual Key
+// field is used to recover the [KeyType] and, for named keys, is the sole
+// source of truth; Runes takes precedence over it for rune keys so that
+// multi-rune input (e.g. some IME compositions) round-trips exactly.
+func (k *Key) fromJSON(aux jsonKey) error {
+	*k = Key{
+		Mod:         parseModStrings(aux.Mod),
+		IsRepeat:    aux.Repeat,
+		IsSynthetic: aux.Synthetic,
+	}
+
+	switch {
+	case aux.Runes != "":
+		k.Type = KeyRunes
+		k.Runes = []rune(aux.Runes)
+	case aux.Key != "":
+		parsed, err := ParseKey(aux.Key)
+		if err != nil {
+			return fmt.Errorf("tea: unmarshal key %q: %w", aux.Key, err)
+		}
+		k.Type = parsed.Type
+		k.Runes = parsed.Runes
+	default:
+		return fmt.Errorf("tea: unmarshal key: missing \"key\" field")
+	}
+
+	if aux.Base != "" {
+		k.baseRune = []rune(aux.Base)[0]
+	}
+	if aux.Alt != "" {
+		k.altRune = []rune(aux.Alt)[0]
+	}
+	return nil
+}
+
+// modStrings returns the active modifiers in mod in the same canonical order
+// used by [Key.String].
+func modStrings(mod KeyMod) []string {
+	var out []string
+	for _, m := range modNames {
+		if mod.Contains(m.mod) {
+			out = append(out, m.name)
+		}
+	}
+	return out
+}
+
+// parseModStrings is the inverse of modStrings.
+func parseModStrings(names []string) KeyMod {
+	var mod KeyMod
+	for _, n := range names {
+		if m, ok := modToken(n); ok {
+			mod |= m
+		}
+	}
+	return mod
+}
+
+// MarshalJSON implements [json.Marshaler], encoding k using the same
+// textual grammar as [ParseKey] plus the structured fields needed for an
+// exact round-trip (runes, the layout-translated base/alt runes, repeat,
+// and whether a repeat was synthesized locally rather than reported by the
+// terminal).
+func (k Key) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.toJSON(""))
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]; see [Key.MarshalJSON].
+func (k *Key) UnmarshalJSON(data []byte) error {
+	var aux jsonKey
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return k.fromJSON(aux)
+}
+
+// MarshalJSON implements [json.Marshaler]. See [Key.MarshalJSON].
+func (k KeyPressMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Key(k).toJSON("press"))
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. See [Key.MarshalJSON].
+func (k *KeyPressMsg) UnmarshalJSON(data []byte) error {
+	var aux jsonKey
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return (*Key)(k).fromJSON(aux)
+}
+
+// MarshalJSON implements [json.Marshaler]. See [Key.MarshalJSON].
+func (k KeyReleaseMsg) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Key(k).toJSON("release"))
+}
+
+// UnmarshalJSON implements [json.Unmarshaler]. See [Key.MarshalJSON].
+func (k *KeyReleaseMsg) UnmarshalJSON(data []byte) error {
+	var aux jsonKey
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	return (*Key)(k).fromJSON(aux)
+}
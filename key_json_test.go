@@ -0,0 +1,23 @@
+package tea
+
+import "testing"
+
+// TestKeyPressMsgJSONSynthetic is a regression test for IsSynthetic being
+// dropped on the wire, which made a synthesized repeat indistinguishable
+// from a terminal-reported one after a round-trip through JSON.
+func TestKeyPressMsgJSONSynthetic(t *testing.T) {
+	in := KeyPressMsg{Type: KeyRunes, Runes: []rune{'a'}, IsRepeat: true, IsSynthetic: true}
+
+	data, err := in.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var out KeyPressMsg
+	if err := out.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	if !out.IsRepeat || !out.IsSynthetic {
+		t.Fatalf("round-trip = %+v, want IsRepeat and IsSynthetic both true", out)
+	}
+}
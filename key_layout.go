@@ -0,0 +1,191 @@
+package tea
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// kpScanFirst and kpScanLast bound the scancode range treated as the keypad
+// block for NumLock purposes. This follows the conventional PC-101 layout,
+// where the keypad occupies a contiguous run of scancodes.
+const (
+	kpScanFirst uint8 = 0x47
+	kpScanLast  uint8 = 0x53
+)
+
+// KeyLayout translates a physical scancode plus the active modifiers into a
+// rune and [KeyType], modelled on X11's keysym lookup tables. It lets hosts
+// that report physical scancodes (the Windows Console API, or Kitty's
+// CSI-u "alternate key" reporting) fill in [Key.baseRune] and the shifted
+// rune even on non-US keyboard layouts, where the terminal alone can't tell
+// Bubble Tea what "the unshifted key" would have been.
+//
+// Table is indexed [scancode][column], where the columns are:
+//
+//	0: base (unshifted)
+//	1: shift
+//	2: mode-switch (AltGr/Mode_switch), unshifted
+//	3: mode-switch, shifted
+//	4: ISO Level 3 Shift, unshifted
+//	5: ISO Level 3 Shift, shifted
+//
+// A column of 0 means "unassigned"; Lookup falls back to the unshifted
+// column in that group, and ultimately to column 0, in that case.
+type KeyLayout struct {
+	Table [256][6]rune
+
+	// NumLockMod, when set, is the modifier Lookup checks to decide whether
+	// keypad scancodes report their digit/operator rune from columns 0/1
+	// (NumLock on) or a navigation [KeyType] such as KeyKpUp, with no rune
+	// at all (NumLock off); see [kpNavType].
+	NumLockMod KeyMod
+
+	// ModeSwitchMod is the modifier that selects columns 2/3, matching
+	// X11's Mode_switch (commonly AltGr on layouts that don't use ISO Level
+	// 3 Shift for it).
+	ModeSwitchMod KeyMod
+
+	// ISOLevel3ShiftMod is the modifier that selects columns 4/5, matching
+	// X11's ISO_Level3_Shift (the usual AltGr binding on ISO keyboards).
+	ISOLevel3ShiftMod KeyMod
+}
+
+// kpNavType maps the keypad scancode block to the [KeyType] it reports when
+// NumLock is off, i.e. functioning as a navigation key (KeyKpUp, KeyKpHome,
+// ...) rather than producing a digit or operator rune.
+var kpNavType = map[uint8]KeyType{
+	0x47: KeyKpHome,
+	0x48: KeyKpUp,
+	0x49: KeyKpPgUp,
+	0x4a: KeyKpMinus,
+	0x4b: KeyKpLeft,
+	0x4c: KeyKpBegin,
+	0x4d: KeyKpRight,
+	0x4e: KeyKpPlus,
+	0x4f: KeyKpEnd,
+	0x50: KeyKpDown,
+	0x51: KeyKpPgDown,
+	0x52: KeyKpInsert,
+	0x53: KeyKpDelete,
+}
+
+// Lookup returns the rune and [KeyType] that scancode produces under mods.
+// It honors shift within whichever column group is active (base, mode
+// switch, or ISO Level 3 Shift), and NumLock for the keypad block: with
+// NumLock on, the keypad reports its digit/operator rune from columns 0/1;
+// with it off, Lookup reports no rune and the navigation [KeyType] the key
+// stands in for (KeyKpUp, KeyKpHome, and so on) instead. If the selected
+// slot is empty (0), it falls back to the unshifted slot in the same group,
+// and failing that to the base unshifted slot.
+func (l *KeyLayout) Lookup(scancode uint8, mods KeyMod) (rune, KeyType) {
+	cols := &l.Table[scancode]
+
+	base, shiftCol := 0, 1
+	switch {
+	case l.ISOLevel3ShiftMod != 0 && mods.Contains(l.ISOLevel3ShiftMod):
+		base, shiftCol = 4, 5
+	case l.ModeSwitchMod != 0 && mods.Contains(l.ModeSwitchMod):
+		base, shiftCol = 2, 3
+	}
+
+	if scancode >= kpScanFirst && scancode <= kpScanLast && l.NumLockMod != 0 {
+		if !mods.Contains(l.NumLockMod) {
+			if t, ok := kpNavType[scancode]; ok {
+				return 0, t
+			}
+		}
+	}
+
+	col := base
+	if mods.Contains(ModShift) {
+		col = shiftCol
+	}
+
+	r := cols[col]
+	if r == 0 && col != base {
+		r = cols[base]
+	}
+	if r == 0 && base != 0 {
+		r = cols[0]
+	}
+	if r == 0 {
+		return 0, KeyRunes
+	}
+	return r, KeyRunes
+}
+
+// LoadXKBSymbols populates l from a simplified XKB symbols dump: one entry
+// per line of the form
+//
+//	<scancode> <base> <shift> [<modeswitch> <modeswitch-shift> [<level3> <level3-shift>]]
+//
+// where each column is either a single rune or a decimal Unicode code point.
+// Blank lines and lines starting with "#" are ignored. This covers dumps
+// produced by flattening `xkbcomp -xkb` output down to the columns
+// [KeyLayout.Table] cares about; it does not parse raw XKB symbols syntax.
+func (l *KeyLayout) LoadXKBSymbols(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		code, err := strconv.ParseUint(fields[0], 0, 8)
+		if err != nil {
+			continue
+		}
+		var cols [6]rune
+		for i, f := range fields[1:] {
+			if i >= len(cols) {
+				break
+			}
+			cols[i] = parseSymField(f)
+		}
+		l.Table[uint8(code)] = cols
+	}
+	return sc.Err()
+}
+
+// parseSymField parses one column of a LoadXKBSymbols line: either a bare
+// rune or a decimal code point.
+func parseSymField(f string) rune {
+	if f == "_" {
+		return 0
+	}
+	if n, err := strconv.ParseInt(f, 10, 32); err == nil {
+		return rune(n)
+	}
+	runes := []rune(f)
+	if len(runes) == 1 {
+		return runes[0]
+	}
+	return 0
+}
+
+// SetKeyLayout installs l as the program's active [KeyLayout]. Hosts that
+// know the physical scancode of each key press (the Windows Console API, or
+// Kitty's CSI-u alternate-key reporting) use it to set [Key.baseRune] to the
+// rune l assigns to that scancode under the active modifiers (shift
+// included) before a [KeyPressMsg] reaches Update, so that Key.String
+// reports the rune of l, not the US rune. Keypad keys that l maps to a
+// navigation [KeyType] get that Type instead.
+//
+// Passing nil disables layout translation.
+func (p *Program) SetKeyLayout(l *KeyLayout) {
+	p.keyLayout = l
+}
+
+// WithKeyLayout is like [Program.SetKeyLayout] but set up front as a
+// [ProgramOption], for hosts that know their layout before the program
+// starts reading input.
+func WithKeyLayout(l *KeyLayout) ProgramOption {
+	return func(p *Program) {
+		p.keyLayout = l
+	}
+}
@@ -0,0 +1,201 @@
+package tea
+
+// Physical scancodes for the alphanumeric block, using the conventional
+// PC/XT "Set 1" numbering also used by the Windows Console API. These are
+// positions on the keyboard, not characters: scanQ is "the key to the right
+// of Tab", which produces 'q' on a US QWERTY board but 'a' on a French
+// AZERTY one.
+const (
+	scan1 uint8 = 0x02
+	scan2 uint8 = 0x03
+	scan3 uint8 = 0x04
+	scan4 uint8 = 0x05
+	scan5 uint8 = 0x06
+	scan6 uint8 = 0x07
+	scan7 uint8 = 0x08
+	scan8 uint8 = 0x09
+	scan9 uint8 = 0x0a
+	scan0 uint8 = 0x0b
+
+	scanQ uint8 = 0x10
+	scanW uint8 = 0x11
+	scanE uint8 = 0x12
+	scanR uint8 = 0x13
+	scanT uint8 = 0x14
+	scanY uint8 = 0x15
+	scanU uint8 = 0x16
+	scanI uint8 = 0x17
+	scanO uint8 = 0x18
+	scanP uint8 = 0x19
+
+	scanA uint8 = 0x1e
+	scanS uint8 = 0x1f
+	scanD uint8 = 0x20
+	scanF uint8 = 0x21
+	scanG uint8 = 0x22
+	scanH uint8 = 0x23
+	scanJ uint8 = 0x24
+	scanK uint8 = 0x25
+	scanL uint8 = 0x26
+
+	scanZ uint8 = 0x2c
+	scanX uint8 = 0x2d
+	scanC uint8 = 0x2e
+	scanV uint8 = 0x2f
+	scanB uint8 = 0x30
+	scanN uint8 = 0x31
+	scanM uint8 = 0x32
+
+	scanMinus  uint8 = 0x0c
+	scanEqual  uint8 = 0x0d
+	scanLBrkt  uint8 = 0x1a
+	scanRBrkt  uint8 = 0x1b
+	scanSemi   uint8 = 0x27
+	scanQuote  uint8 = 0x28
+	scanGrave  uint8 = 0x29
+	scanBslash uint8 = 0x2b
+	scanComma  uint8 = 0x33
+	scanPeriod uint8 = 0x34
+	scanSlash  uint8 = 0x35
+)
+
+// set assigns the base and shifted runes for scancode on l.
+func (l *KeyLayout) set(scancode uint8, base, shift rune) {
+	l.Table[scancode][0] = base
+	l.Table[scancode][1] = shift
+}
+
+// setAltGr assigns the rune scancode produces under the layout's AltGr key
+// (ISO Level 3 Shift on the layouts below), such as "@" or "€".
+func (l *KeyLayout) setAltGr(scancode uint8, r rune) {
+	l.Table[scancode][4] = r
+}
+
+// newQWERTYLayout builds the common Latin QWERTY skeleton shared by the US
+// and UK layouts, which only differ in a handful of punctuation keys.
+func newQWERTYLayout() *KeyLayout {
+	l := &KeyLayout{}
+	rows := []struct {
+		scan        uint8
+		base, shift rune
+	}{
+		{scan1, '1', '!'}, {scan2, '2', '@'}, {scan3, '3', '#'}, {scan4, '4', '$'},
+		{scan5, '5', '%'}, {scan6, '6', '^'}, {scan7, '7', '&'}, {scan8, '8', '*'},
+		{scan9, '9', '('}, {scan0, '0', ')'},
+		{scanQ, 'q', 'Q'}, {scanW, 'w', 'W'}, {scanE, 'e', 'E'}, {scanR, 'r', 'R'},
+		{scanT, 't', 'T'}, {scanY, 'y', 'Y'}, {scanU, 'u', 'U'}, {scanI, 'i', 'I'},
+		{scanO, 'o', 'O'}, {scanP, 'p', 'P'},
+		{scanA, 'a', 'A'}, {scanS, 's', 'S'}, {scanD, 'd', 'D'}, {scanF, 'f', 'F'},
+		{scanG, 'g', 'G'}, {scanH, 'h', 'H'}, {scanJ, 'j', 'J'}, {scanK, 'k', 'K'},
+		{scanL, 'l', 'L'},
+		{scanZ, 'z', 'Z'}, {scanX, 'x', 'X'}, {scanC, 'c', 'C'}, {scanV, 'v', 'V'},
+		{scanB, 'b', 'B'}, {scanN, 'n', 'N'}, {scanM, 'm', 'M'},
+		{scanMinus, '-', '_'}, {scanEqual, '=', '+'},
+		{scanLBrkt, '[', '{'}, {scanRBrkt, ']', '}'},
+		{scanSemi, ';', ':'}, {scanQuote, '\'', '"'}, {scanGrave, '`', '~'},
+		{scanBslash, '\\', '|'}, {scanComma, ',', '<'}, {scanPeriod, '.', '>'},
+		{scanSlash, '/', '?'},
+	}
+	for _, r := range rows {
+		l.set(r.scan, r.base, r.shift)
+	}
+	return l
+}
+
+// LayoutUS is the 101-key US QWERTY layout.
+var LayoutUS = func() *KeyLayout {
+	l := newQWERTYLayout()
+	l.NumLockMod = ModNumLock
+	return l
+}()
+
+// LayoutUK is the UK QWERTY layout, differing from [LayoutUS] in the
+// punctuation keys around the Enter key and the number row.
+var LayoutUK = func() *KeyLayout {
+	l := newQWERTYLayout()
+	l.NumLockMod = ModNumLock
+	l.set(scan2, '2', '"')
+	l.set(scan3, '3', '£')
+	l.set(scanQuote, '\'', '@')
+	l.set(scanBslash, '#', '~')
+	return l
+}()
+
+// LayoutDE is the German QWERTZ layout: Y and Z are swapped relative to
+// QWERTY, and most punctuation keys carry umlauts or German-specific
+// symbols instead. AltGr (ISO Level 3 Shift) produces "@", "€", and the
+// bracket/brace keys that the base layout has no room for.
+//
+// AltGr also produces "\" and "µ"; the ISO Level 3 Shift slots of all other
+// keys are left empty.
+var LayoutDE = func() *KeyLayout {
+	l := newQWERTYLayout()
+	l.NumLockMod = ModNumLock
+	l.ISOLevel3ShiftMod = ModAlt
+	l.set(scanY, 'z', 'Z')
+	l.set(scanZ, 'y', 'Y')
+	l.set(scan6, '6', '&')
+	l.set(scan7, '7', '/')
+	l.set(scan8, '8', '(')
+	l.set(scan9, '9', ')')
+	l.set(scan0, '0', '=')
+	l.set(scanMinus, 'ß', '?')
+	l.set(scanLBrkt, 'ü', 'Ü')
+	l.set(scanRBrkt, '+', '*')
+	l.set(scanSemi, 'ö', 'Ö')
+	l.set(scanQuote, 'ä', 'Ä')
+	l.set(scanBslash, '#', '\'')
+	l.set(scanComma, ',', ';')
+	l.set(scanPeriod, '.', ':')
+	l.set(scanSlash, '-', '_')
+	l.setAltGr(scanQ, '@')
+	l.setAltGr(scanE, '€')
+	l.setAltGr(scan7, '{')
+	l.setAltGr(scan8, '[')
+	l.setAltGr(scan9, ']')
+	l.setAltGr(scan0, '}')
+	l.setAltGr(scanMinus, '\\')
+	l.setAltGr(scanM, 'µ')
+	return l
+}()
+
+// LayoutFR is the French AZERTY layout: the number row produces symbols
+// unshifted and digits with shift, A/Q and W/Z swap places, and M sits on
+// the key QWERTY uses for ";". AltGr (ISO Level 3 Shift) produces "@", "€", and the
+// bracket/brace keys the base layout has no room for.
+//
+// TODO: covers only the handful of AltGr combinations above; the rest of
+// the ISO Level 3 Shift column is not yet populated.
+var LayoutFR = func() *KeyLayout {
+	l := &KeyLayout{NumLockMod: ModNumLock, ISOLevel3ShiftMod: ModAlt}
+	rows := []struct {
+		scan        uint8
+		base, shift rune
+	}{
+		{scan1, '&', '1'}, {scan2, 'é', '2'}, {scan3, '"', '3'}, {scan4, '\'', '4'},
+		{scan5, '(', '5'}, {scan6, '-', '6'}, {scan7, 'è', '7'}, {scan8, '_', '8'},
+		{scan9, 'ç', '9'}, {scan0, 'à', '0'},
+		{scanQ, 'a', 'A'}, {scanW, 'z', 'Z'}, {scanE, 'e', 'E'}, {scanR, 'r', 'R'},
+		{scanT, 't', 'T'}, {scanY, 'y', 'Y'}, {scanU, 'u', 'U'}, {scanI, 'i', 'I'},
+		{scanO, 'o', 'O'}, {scanP, 'p', 'P'},
+		{scanA, 'q', 'Q'}, {scanS, 's', 'S'}, {scanD, 'd', 'D'}, {scanF, 'f', 'F'},
+		{scanG, 'g', 'G'}, {scanH, 'h', 'H'}, {scanJ, 'j', 'J'}, {scanK, 'k', 'K'},
+		{scanL, 'l', 'L'},
+		{scanZ, 'w', 'W'}, {scanX, 'x', 'X'}, {scanC, 'c', 'C'}, {scanV, 'v', 'V'},
+		{scanB, 'b', 'B'}, {scanN, 'n', 'N'}, {scanM, ',', '?'},
+		{scanSemi, 'm', 'M'}, {scanComma, ';', '.'}, {scanPeriod, ':', '/'},
+		{scanSlash, '!', '§'},
+		{scanMinus, ')', '°'}, {scanEqual, '=', '+'},
+		{scanGrave, '²', 0},
+	}
+	for _, r := range rows {
+		l.set(r.scan, r.base, r.shift)
+	}
+	l.setAltGr(scan0, '@')
+	l.setAltGr(scanE, '€')
+	l.setAltGr(scan4, '{')
+	l.setAltGr(scan5, '[')
+	l.setAltGr(scan6, ']')
+	l.setAltGr(scanEqual, '}')
+	return l
+}()
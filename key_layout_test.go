@@ -0,0 +1,22 @@
+package tea
+
+import "testing"
+
+// TestKeyLayoutLookupNumLock is a regression test for a bug where the
+// NumLock-off case never selected the keypad's navigation [KeyType]s,
+// leaving Lookup to always report KeyRunes.
+func TestKeyLayoutLookupNumLock(t *testing.T) {
+	l := LayoutUS
+
+	// With NumLock on, the keypad block falls through to its (unpopulated)
+	// digit/operator columns, not a navigation KeyType.
+	if r, typ := l.Lookup(0x48, ModNumLock); r != 0 || typ != KeyRunes {
+		t.Fatalf("Lookup(kp8, NumLock on) = (%q, %v), want (0, KeyRunes)", r, typ)
+	}
+
+	// With NumLock off, the same scancode reports its navigation KeyType
+	// and no rune.
+	if r, typ := l.Lookup(0x48, 0); r != 0 || typ != KeyKpUp {
+		t.Fatalf("Lookup(kp8, NumLock off) = (%q, %v), want (0, KeyKpUp)", r, typ)
+	}
+}
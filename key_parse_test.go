@@ -0,0 +1,61 @@
+package tea
+
+import "testing"
+
+func TestParseKeyRoundTrip(t *testing.T) {
+	for kt, name := range keyTypeString {
+		if kt == KeyRunes {
+			continue
+		}
+		k := Key{Type: kt}
+		want := k.String()
+		if want == "" {
+			continue
+		}
+		got, err := ParseKey(want)
+		if err != nil {
+			t.Errorf("ParseKey(%q) [%s]: %v", want, name, err)
+			continue
+		}
+		if got.String() != want {
+			t.Errorf("ParseKey(%q).String() = %q, want %q", want, got.String(), want)
+		}
+	}
+}
+
+func TestParseKeyRoundTripRunes(t *testing.T) {
+	runes := []rune{'a', 'A', 'z', 'Z', '0', '9', ' ', '你', '+'}
+	mods := []KeyMod{0, ModShift, ModCtrl, ModCtrl | ModAlt | ModShift}
+
+	for _, r := range runes {
+		for _, mod := range mods {
+			k := Key{Type: KeyRunes, Runes: []rune{r}, Mod: mod}
+			want := k.String()
+			got, err := ParseKey(want)
+			if err != nil {
+				t.Errorf("ParseKey(%q): %v", want, err)
+				continue
+			}
+			if got.String() != want {
+				t.Errorf("ParseKey(%q).String() = %q, want %q", want, got.String(), want)
+			}
+		}
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	for _, s := range []string{"", "ctrl+", "bogus+a", "nosuchkey"} {
+		if _, err := ParseKey(s); err == nil {
+			t.Errorf("ParseKey(%q): expected error, got nil", s)
+		}
+	}
+}
+
+func TestMustParseKeyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustParseKey: expected panic on invalid input")
+		}
+	}()
+	MustParseKey("nosuchkey")
+}
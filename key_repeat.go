@@ -0,0 +1,132 @@
+package tea
+
+import "time"
+
+// DefaultSyntheticKeyRepeatMin and DefaultSyntheticKeyRepeatMax bound the
+// interval between two identical key presses that Bubble Tea will treat as
+// an OS-style autorepeat when the terminal doesn't report repeats itself.
+const (
+	DefaultSyntheticKeyRepeatMin = 40 * time.Millisecond
+	DefaultSyntheticKeyRepeatMax = 500 * time.Millisecond
+)
+
+// keyRepeatRingSize is the number of recent key presses kept to detect a
+// repeat against.
+const keyRepeatRingSize = 8
+
+// keyRepeatEntry is one slot in the synthetic repeat detector's ring buffer.
+type keyRepeatEntry struct {
+	key Key
+	at  time.Time
+	set bool
+}
+
+// keyRepeatDetector heuristically marks [KeyPressMsg.IsRepeat] when the
+// terminal doesn't enable the Kitty keyboard protocol's "report event
+// types" flag, and so never reports IsRepeat itself. It keeps a small ring
+// of the last few keys pressed and their timestamps; an identical key
+// arriving again within [keyRepeatDetector.min] to [keyRepeatDetector.max]
+// of the previous one is treated as a held-down repeat.
+//
+// The first time a terminal reports IsRepeat on its own, the detector takes
+// that as proof the terminal handles repeats natively and stops guessing:
+// a host that reports real repeats can also send two genuinely distinct
+// fast presses of the same key, which the heuristic alone can't tell apart
+// from a repeat.
+type keyRepeatDetector struct {
+	ring       [keyRepeatRingSize]keyRepeatEntry
+	next       int
+	min, max   time.Duration
+	nativeSeen bool
+}
+
+func newKeyRepeatDetector(min, max time.Duration) *keyRepeatDetector {
+	return &keyRepeatDetector{min: min, max: max}
+}
+
+// process inspects msg against recently seen keys and, if it looks like a
+// repeat of the most recent matching key, returns a copy with IsRepeat and
+// IsSynthetic set. It always records msg into the ring for future lookups.
+//
+// process never overrides a key the terminal already flagged as a repeat:
+// if msg.IsRepeat is already true, it is returned unchanged, and the
+// heuristic is disabled for the rest of the program's lifetime (see
+// [keyRepeatDetector]).
+func (d *keyRepeatDetector) process(msg KeyPressMsg, now time.Time) KeyPressMsg {
+	if msg.IsRepeat {
+		d.nativeSeen = true
+		d.record(Key(msg), now)
+		return msg
+	}
+	if d.nativeSeen {
+		d.record(Key(msg), now)
+		return msg
+	}
+
+	k := Key(msg)
+	// Walk the ring from newest to oldest and judge only the most recent
+	// matching key.
+	for i := 1; i <= keyRepeatRingSize; i++ {
+		e := d.ring[(d.next-i+keyRepeatRingSize)%keyRepeatRingSize]
+		if !e.set || !keysEqual(e.key, k) {
+			continue
+		}
+		delta := now.Sub(e.at)
+		if delta >= d.min && delta <= d.max {
+			msg.IsRepeat = true
+			msg.IsSynthetic = true
+		}
+		break
+	}
+
+	d.record(k, now)
+	return msg
+}
+
+func (d *keyRepeatDetector) record(k Key, at time.Time) {
+	k.IsRepeat = false
+	k.IsSynthetic = false
+	d.ring[d.next] = keyRepeatEntry{key: k, at: at, set: true}
+	d.next = (d.next + 1) % keyRepeatRingSize
+}
+
+// keysEqual compares two keys ignoring their IsRepeat/IsSynthetic flags,
+// which are set by the very detection this helper supports.
+func keysEqual(a, b Key) bool {
+	if a.Type != b.Type || a.Mod != b.Mod || a.baseRune != b.baseRune || a.altRune != b.altRune {
+		return false
+	}
+	if len(a.Runes) != len(b.Runes) {
+		return false
+	}
+	for i := range a.Runes {
+		if a.Runes[i] != b.Runes[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WithSyntheticKeyRepeat enables Bubble Tea's heuristic key-repeat
+// detection for terminals that don't report repeats themselves (i.e.
+// anything without the Kitty keyboard protocol's "report event types"
+// flag). An identical key arriving again within [min, max] of the previous
+// one is reported with IsRepeat and [Key.IsSynthetic] both set to true.
+//
+// This is enabled by default with [DefaultSyntheticKeyRepeatMin] and
+// [DefaultSyntheticKeyRepeatMax]; use this option to change the bounds, or
+// [WithoutSyntheticKeyRepeat] to disable it entirely.
+func WithSyntheticKeyRepeat(min, max time.Duration) ProgramOption {
+	return func(p *Program) {
+		p.keyRepeat = newKeyRepeatDetector(min, max)
+	}
+}
+
+// WithoutSyntheticKeyRepeat disables Bubble Tea's heuristic key-repeat
+// detection, so IsRepeat is only ever set when the terminal reports it
+// directly. See [WithSyntheticKeyRepeat].
+func WithoutSyntheticKeyRepeat() ProgramOption {
+	return func(p *Program) {
+		p.keyRepeat = nil
+	}
+}
@@ -0,0 +1,47 @@
+package tea
+
+import (
+	"testing"
+	"time"
+)
+
+// TestKeyRepeatDetectorDisablesOnceNativeRepeatSeen is a regression test for
+// a bug where the synthetic repeat heuristic kept running even after the
+// terminal proved it reports repeats natively, corrupting IsRepeat for two
+// genuinely distinct fast presses of the same key.
+func TestKeyRepeatDetectorDisablesOnceNativeRepeatSeen(t *testing.T) {
+	d := newKeyRepeatDetector(10*time.Millisecond, time.Second)
+	base := time.Now()
+	a := KeyPressMsg{Type: KeyRunes, Runes: []rune{'a'}}
+
+	// A native repeat report disables the heuristic from here on.
+	native := a
+	native.IsRepeat = true
+	if out := d.process(native, base); !out.IsRepeat || out.IsSynthetic {
+		t.Fatalf("process(native repeat) = %+v, want IsRepeat true, IsSynthetic false", out)
+	}
+
+	// Without the gate, two fast presses of 'a' within [min, max] would be
+	// heuristically flagged as a repeat; with native reporting already
+	// observed, they must be left alone.
+	out := d.process(a, base.Add(50*time.Millisecond))
+	if out.IsRepeat || out.IsSynthetic {
+		t.Fatalf("process(distinct press after native repeat seen) = %+v, want IsRepeat/IsSynthetic both false", out)
+	}
+}
+
+func TestKeyRepeatDetectorHeuristic(t *testing.T) {
+	d := newKeyRepeatDetector(10*time.Millisecond, 100*time.Millisecond)
+	base := time.Now()
+	a := KeyPressMsg{Type: KeyRunes, Runes: []rune{'a'}}
+
+	first := d.process(a, base)
+	if first.IsRepeat {
+		t.Fatalf("process(first press) = %+v, want IsRepeat false", first)
+	}
+
+	second := d.process(a, base.Add(20*time.Millisecond))
+	if !second.IsRepeat || !second.IsSynthetic {
+		t.Fatalf("process(fast second press) = %+v, want IsRepeat and IsSynthetic true", second)
+	}
+}
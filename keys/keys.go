@@ -0,0 +1,266 @@
+// Package keys provides a small chord/sequence keybinding matcher built on
+// top of [tea.KeyPressMsg]. It lets applications describe bindings like
+// Emacs- or Kakoune-style prefix maps ("ctrl+x ctrl+s" to save) without
+// hand-rolling a state machine in every Update.
+package keys
+
+import (
+	"time"
+
+	tea "github.com/xiaohunqupo/bubbletea"
+)
+
+// DefaultSequenceTimeout is the duration a partially matched sequence waits
+// for its next keystroke before it is abandoned.
+const DefaultSequenceTimeout = time.Second
+
+// Binding describes one keybinding: either a set of interchangeable single
+// strokes (see [Keys]) or an ordered chord sequence (see [Sequence]).
+type Binding struct {
+	strokes []string
+	chord   bool
+	help    string
+}
+
+// Keys returns a Binding that matches any one of the given strokes, each
+// parsed using the same grammar as [tea.ParseKey] (e.g. "ctrl+c", "f1").
+// It panics if a stroke can't be parsed, the same way [tea.MustParseKey]
+// does, since bindings are normally built from static strings in program
+// source.
+func Keys(strokes ...string) Binding {
+	return Binding{strokes: normalizeStrokes(strokes)}
+}
+
+// Sequence returns a Binding that matches only when the given strokes are
+// pressed in order, one after another, such as "ctrl+x", "ctrl+c". It
+// panics under the same conditions as [Keys].
+func Sequence(strokes ...string) Binding {
+	return Binding{strokes: normalizeStrokes(strokes), chord: true}
+}
+
+// normalizeStrokes parses each stroke and renders it back through
+// [tea.Key.String], so that matching against an incoming key's own String
+// form never has to worry about non-canonical modifier order (e.g.
+// "shift+ctrl+x" vs the canonical "ctrl+shift+x").
+func normalizeStrokes(strokes []string) []string {
+	out := make([]string, len(strokes))
+	for i, s := range strokes {
+		out[i] = tea.MustParseKey(s).String()
+	}
+	return out
+}
+
+// WithHelp attaches a help string to the binding and returns the updated
+// copy, so bindings can be declared and documented in one expression.
+func (b Binding) WithHelp(help string) Binding {
+	b.help = help
+	return b
+}
+
+// Help returns the help string attached to the binding, if any.
+func (b Binding) Help() string {
+	return b.help
+}
+
+// Strokes returns the key strokes that make up the binding, in the textual
+// form accepted by [tea.ParseKey].
+func (b Binding) Strokes() []string {
+	return b.strokes
+}
+
+// IsSequence reports whether the binding is an ordered chord sequence, as
+// opposed to a set of interchangeable single strokes.
+func (b Binding) IsSequence() bool {
+	return b.chord
+}
+
+// KeyMap is a named collection of bindings that a [Matcher] resolves
+// incoming key presses against.
+type KeyMap struct {
+	bindings []Binding
+}
+
+// NewKeyMap builds a KeyMap from the given bindings.
+func NewKeyMap(bindings ...Binding) *KeyMap {
+	return &KeyMap{bindings: bindings}
+}
+
+// Bindings returns the bindings in the map.
+func (m *KeyMap) Bindings() []Binding {
+	return m.bindings
+}
+
+// SequenceTimeoutMsg is sent when a partially matched chord sequence has not
+// received its next keystroke within the matcher's configured timeout. Models
+// should route it to their [Matcher] so the pending buffer is flushed even
+// when no further input arrives.
+type SequenceTimeoutMsg struct {
+	// generation identifies which pending sequence this timeout refers to,
+	// so a Matcher can ignore timeouts that arrived after the sequence they
+	// were scheduled for already resolved.
+	generation uint64
+}
+
+// MatchResult describes the outcome of feeding a key press to a [Matcher].
+type MatchResult int
+
+const (
+	// NoMatch means the key press did not extend or complete any binding.
+	NoMatch MatchResult = iota
+	// PartialMatch means the key press is a valid prefix of one or more
+	// sequence bindings, and the Matcher is now waiting for more input.
+	PartialMatch
+	// Matched means the key press completed a binding.
+	Matched
+)
+
+// Matcher resolves a stream of [tea.KeyPressMsg] against a [KeyMap],
+// tracking a small pending buffer of recent strokes so that chord sequences
+// (e.g. "ctrl+x ctrl+s") can be recognized across multiple key presses.
+type Matcher struct {
+	keymap     *KeyMap
+	timeout    time.Duration
+	pending    []string
+	generation uint64
+}
+
+// NewMatcher creates a Matcher for km using [DefaultSequenceTimeout].
+func NewMatcher(km *KeyMap) *Matcher {
+	return &Matcher{keymap: km, timeout: DefaultSequenceTimeout}
+}
+
+// SetTimeout changes how long a partial sequence waits for its next
+// keystroke before [Matcher.Feed] abandons it.
+func (m *Matcher) SetTimeout(d time.Duration) {
+	m.timeout = d
+}
+
+// Feed processes a single key press and reports whether it completed a
+// binding, extended a pending sequence, or matched nothing at all. When it
+// returns PartialMatch, the returned Cmd must be executed so the pending
+// buffer is flushed via a [SequenceTimeoutMsg] if no further input arrives.
+func (m *Matcher) Feed(msg tea.KeyPressMsg) (Binding, MatchResult, tea.Cmd) {
+	stroke := tea.Key(msg).String()
+
+	// A pending chord always gets first refusal: if this stroke completes
+	// or extends it, that takes precedence over any single-stroke binding
+	// that happens to share the same final key (e.g. a lone Keys("ctrl+s")
+	// binding coexisting with Sequence("ctrl+x", "ctrl+s")).
+	if len(m.pending) > 0 {
+		candidate := append(append([]string{}, m.pending...), stroke)
+
+		if b, ok := m.matchExact(candidate); ok {
+			m.reset()
+			return b, Matched, nil
+		}
+
+		if m.hasPrefix(candidate) {
+			return m.arm(candidate)
+		}
+
+		// The stroke doesn't continue the pending sequence. Abandon it and
+		// reconsider the stroke on its own below: it may itself be the
+		// first stroke of a different sequence, or a single-stroke match.
+		m.reset()
+	}
+
+	if b, ok := m.matchSingle(stroke); ok {
+		return b, Matched, nil
+	}
+
+	if b, ok := m.matchExact([]string{stroke}); ok {
+		return b, Matched, nil
+	}
+
+	if m.hasPrefix([]string{stroke}) {
+		return m.arm([]string{stroke})
+	}
+
+	return Binding{}, NoMatch, nil
+}
+
+// arm records strokes as the new pending sequence and returns the
+// PartialMatch result along with the Cmd that flushes it via a
+// [SequenceTimeoutMsg] if no further input arrives in time.
+func (m *Matcher) arm(strokes []string) (Binding, MatchResult, tea.Cmd) {
+	m.pending = strokes
+	m.generation++
+	gen := m.generation
+	return Binding{}, PartialMatch, func() tea.Msg {
+		timer := time.NewTimer(m.timeout)
+		<-timer.C
+		return SequenceTimeoutMsg{generation: gen}
+	}
+}
+
+// HandleTimeout applies a [SequenceTimeoutMsg], flushing the pending buffer
+// if it still belongs to the sequence the timeout was scheduled for. It
+// reports whether the buffer was flushed.
+func (m *Matcher) HandleTimeout(msg SequenceTimeoutMsg) bool {
+	if msg.generation != m.generation || len(m.pending) == 0 {
+		// A newer keystroke already resolved or replaced this sequence.
+		return false
+	}
+	m.reset()
+	return true
+}
+
+func (m *Matcher) reset() {
+	m.pending = nil
+}
+
+// matchSingle checks stroke against every non-chord Keys(...) binding.
+// stroke and every b.strokes entry are both in [tea.Key.String]'s canonical
+// form (see [normalizeStrokes]), so a plain equality check is enough.
+func (m *Matcher) matchSingle(stroke string) (Binding, bool) {
+	for _, b := range m.keymap.bindings {
+		if b.chord {
+			continue
+		}
+		for _, s := range b.strokes {
+			if s == stroke {
+				return b, true
+			}
+		}
+	}
+	return Binding{}, false
+}
+
+// matchExact checks whether strokes exactly completes a chord binding.
+func (m *Matcher) matchExact(strokes []string) (Binding, bool) {
+	for _, b := range m.keymap.bindings {
+		if !b.chord || len(b.strokes) != len(strokes) {
+			continue
+		}
+		if equalStrokes(b.strokes, strokes) {
+			return b, true
+		}
+	}
+	return Binding{}, false
+}
+
+// hasPrefix reports whether strokes is a strict, non-empty prefix of any
+// chord binding in the keymap.
+func (m *Matcher) hasPrefix(strokes []string) bool {
+	for _, b := range m.keymap.bindings {
+		if !b.chord || len(b.strokes) <= len(strokes) {
+			continue
+		}
+		if equalStrokes(b.strokes[:len(strokes)], strokes) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalStrokes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
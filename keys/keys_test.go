@@ -0,0 +1,111 @@
+package keys
+
+import (
+	"testing"
+
+	tea "github.com/xiaohunqupo/bubbletea"
+)
+
+func press(stroke string) tea.KeyPressMsg {
+	return tea.KeyPressMsg(tea.MustParseKey(stroke))
+}
+
+func TestMatcherSingleStroke(t *testing.T) {
+	save := Keys("ctrl+s").WithHelp("save")
+	km := NewKeyMap(save)
+	m := NewMatcher(km)
+
+	b, res, cmd := m.Feed(press("ctrl+s"))
+	if res != Matched {
+		t.Fatalf("Feed: got %v, want Matched", res)
+	}
+	if cmd != nil {
+		t.Fatalf("Feed: got non-nil Cmd for a Matched result")
+	}
+	if b.Help() != "save" {
+		t.Fatalf("Feed: got binding %q, want %q", b.Help(), "save")
+	}
+}
+
+func TestMatcherNonCanonicalModifierOrder(t *testing.T) {
+	// "shift+ctrl+x" and "ctrl+shift+x" name the same key; Keys should
+	// normalize both to the canonical form so either spelling matches.
+	km := NewKeyMap(Keys("shift+ctrl+x"))
+	m := NewMatcher(km)
+
+	if _, res, _ := m.Feed(press("ctrl+shift+x")); res != Matched {
+		t.Fatalf("Feed: got %v, want Matched", res)
+	}
+}
+
+func TestMatcherSequence(t *testing.T) {
+	save := Sequence("ctrl+x", "ctrl+s").WithHelp("save")
+	km := NewKeyMap(save)
+	m := NewMatcher(km)
+
+	if _, res, cmd := m.Feed(press("ctrl+x")); res != PartialMatch || cmd == nil {
+		t.Fatalf("Feed(ctrl+x): got (%v, cmd==nil %v), want (PartialMatch, false)", res, cmd == nil)
+	}
+	b, res, _ := m.Feed(press("ctrl+s"))
+	if res != Matched {
+		t.Fatalf("Feed(ctrl+s): got %v, want Matched", res)
+	}
+	if b.Help() != "save" {
+		t.Fatalf("Feed(ctrl+s): got binding %q, want %q", b.Help(), "save")
+	}
+}
+
+// TestMatcherSequenceTakesPrecedenceOverSingleStroke is a regression test
+// for a bug where a pending chord lost to a single-stroke binding that
+// happened to share the chord's final key: with both Keys("ctrl+s") and
+// Sequence("ctrl+x", "ctrl+s") registered, pressing ctrl+x then ctrl+s must
+// complete the sequence, not the unrelated single-stroke binding.
+func TestMatcherSequenceTakesPrecedenceOverSingleStroke(t *testing.T) {
+	saveSingle := Keys("ctrl+s").WithHelp("single")
+	saveSequence := Sequence("ctrl+x", "ctrl+s").WithHelp("sequence")
+	km := NewKeyMap(saveSingle, saveSequence)
+	m := NewMatcher(km)
+
+	if _, res, _ := m.Feed(press("ctrl+x")); res != PartialMatch {
+		t.Fatalf("Feed(ctrl+x): got %v, want PartialMatch", res)
+	}
+	b, res, _ := m.Feed(press("ctrl+s"))
+	if res != Matched {
+		t.Fatalf("Feed(ctrl+s): got %v, want Matched", res)
+	}
+	if b.Help() != "sequence" {
+		t.Fatalf("Feed(ctrl+s): got binding %q, want %q", b.Help(), "sequence")
+	}
+}
+
+func TestMatcherSequenceAbandonedOnMismatch(t *testing.T) {
+	km := NewKeyMap(Sequence("ctrl+x", "ctrl+s"))
+	m := NewMatcher(km)
+
+	if _, res, _ := m.Feed(press("ctrl+x")); res != PartialMatch {
+		t.Fatalf("Feed(ctrl+x): got %v, want PartialMatch", res)
+	}
+	if _, res, _ := m.Feed(press("a")); res != NoMatch {
+		t.Fatalf("Feed(a): got %v, want NoMatch", res)
+	}
+}
+
+func TestMatcherHandleTimeout(t *testing.T) {
+	km := NewKeyMap(Sequence("ctrl+x", "ctrl+s"))
+	m := NewMatcher(km)
+
+	if _, res, _ := m.Feed(press("ctrl+x")); res != PartialMatch {
+		t.Fatalf("Feed(ctrl+x): got %v, want PartialMatch", res)
+	}
+	if !m.HandleTimeout(SequenceTimeoutMsg{generation: m.generation}) {
+		t.Fatalf("HandleTimeout: got false for the current generation")
+	}
+	// A stale timeout from an already-resolved generation must not flush
+	// a newer pending sequence.
+	if _, res, _ := m.Feed(press("ctrl+x")); res != PartialMatch {
+		t.Fatalf("Feed(ctrl+x): got %v, want PartialMatch", res)
+	}
+	if m.HandleTimeout(SequenceTimeoutMsg{generation: m.generation - 1}) {
+		t.Fatalf("HandleTimeout: got true for a stale generation")
+	}
+}
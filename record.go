@@ -0,0 +1,197 @@
+package tea
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// recordedEvent is one line of a recorded input stream: a message kind, its
+// JSON-encoded payload, and how long after the start of the recording it
+// arrived.
+type recordedEvent struct {
+	At   time.Duration   `json:"at"`
+	Kind string          `json:"kind"`
+	Msg  json.RawMessage `json:"msg"`
+}
+
+const (
+	recordKindKeyPress     = "key_press"
+	recordKindKeyRelease   = "key_release"
+	recordKindWindowSize   = "window_size"
+	recordKindMouseClick   = "mouse_click"
+	recordKindMouseRelease = "mouse_release"
+	recordKindMouseWheel   = "mouse_wheel"
+	recordKindMouseMotion  = "mouse_motion"
+)
+
+// recorder serializes a stream of input [Msg] values to w as
+// newline-delimited JSON, one [recordedEvent] per line, timestamped
+// relative to when the recorder was created.
+type recorder struct {
+	w       io.Writer
+	started time.Time
+	enc     *json.Encoder
+}
+
+func newRecorder(w io.Writer, now time.Time) *recorder {
+	return &recorder{w: w, started: now, enc: json.NewEncoder(w)}
+}
+
+// record writes msg to the recording if it's a kind [RecordInput] knows how
+// to serialize; other message types are silently skipped, the same way an
+// unhandled message type would be silently dropped by Update.
+func (r *recorder) record(msg Msg, now time.Time) error {
+	var ev recordedEvent
+	ev.At = now.Sub(r.started)
+
+	switch msg.(type) {
+	case KeyPressMsg:
+		ev.Kind = recordKindKeyPress
+	case KeyReleaseMsg:
+		ev.Kind = recordKindKeyRelease
+	case WindowSizeMsg:
+		ev.Kind = recordKindWindowSize
+	case MouseClickMsg:
+		ev.Kind = recordKindMouseClick
+	case MouseReleaseMsg:
+		ev.Kind = recordKindMouseRelease
+	case MouseWheelMsg:
+		ev.Kind = recordKindMouseWheel
+	case MouseMotionMsg:
+		ev.Kind = recordKindMouseMotion
+	default:
+		return nil
+	}
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("tea: recording %s: %w", ev.Kind, err)
+	}
+	ev.Msg = payload
+	return r.enc.Encode(ev)
+}
+
+// RecordInput returns a [ProgramOption] that writes every key, mouse, and
+// window-size message the program receives to w as a newline-delimited JSON
+// stream, suitable for later deterministic playback with [ReplayInput].
+// This is useful for reproducing bugs, snapshot-testing TUIs, and building
+// demo scripts from a real session.
+func RecordInput(w io.Writer) ProgramOption {
+	return func(p *Program) {
+		p.recordTo = w
+	}
+}
+
+// replayer reads a recording produced by [RecordInput] and delivers its
+// events to a program, honoring the original inter-event delays scaled by
+// speed.
+type replayer struct {
+	dec   *json.Decoder
+	speed float64
+}
+
+func newReplayer(r io.Reader) *replayer {
+	return &replayer{dec: json.NewDecoder(bufio.NewReader(r)), speed: 1}
+}
+
+// setSpeed scales the delay between replayed events; 2 plays back twice as
+// fast, 0.5 half as fast. A speed of 0 replays every event immediately.
+func (rp *replayer) setSpeed(speed float64) {
+	rp.speed = speed
+}
+
+// next decodes the next event in the recording, or returns io.EOF once the
+// stream is exhausted.
+func (rp *replayer) next() (recordedEvent, error) {
+	var ev recordedEvent
+	if err := rp.dec.Decode(&ev); err != nil {
+		return recordedEvent{}, err
+	}
+	return ev, nil
+}
+
+// delay returns how long to wait before delivering ev relative to the start
+// of replay, honoring the configured speed.
+func (rp *replayer) delay(ev recordedEvent, elapsed time.Duration) time.Duration {
+	if rp.speed <= 0 {
+		return 0
+	}
+	target := time.Duration(float64(ev.At) / rp.speed)
+	if target <= elapsed {
+		return 0
+	}
+	return target - elapsed
+}
+
+// decodeMsg decodes ev's payload into the concrete message type named by
+// its Kind.
+func decodeMsg(ev recordedEvent) (Msg, error) {
+	var (
+		msg Msg
+		err error
+	)
+	switch ev.Kind {
+	case recordKindKeyPress:
+		var m KeyPressMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindKeyRelease:
+		var m KeyReleaseMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindWindowSize:
+		var m WindowSizeMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindMouseClick:
+		var m MouseClickMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindMouseRelease:
+		var m MouseReleaseMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindMouseWheel:
+		var m MouseWheelMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	case recordKindMouseMotion:
+		var m MouseMotionMsg
+		err = json.Unmarshal(ev.Msg, &m)
+		msg = m
+	default:
+		return nil, fmt.Errorf("tea: replay: unknown event kind %q", ev.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tea: replay: decoding %s: %w", ev.Kind, err)
+	}
+	return msg, nil
+}
+
+// ReplayInput returns a [ProgramOption] that, instead of reading from the
+// real terminal, feeds the program messages decoded from a recording made
+// with [RecordInput]. By default each event is delivered after the same
+// delay it originally arrived with, relative to the previous event; use
+// [Speed] to play back faster, slower, or (with 0) as fast as possible.
+func ReplayInput(r io.Reader) ProgramOption {
+	return func(p *Program) {
+		p.replayFrom = newReplayer(r)
+	}
+}
+
+// Speed returns a [ProgramOption] that scales the playback speed of a
+// recording set up with [ReplayInput]. It has no effect without
+// ReplayInput. A speed of 2 plays back twice as fast as recorded, 0.5 half
+// as fast, and 0 delivers every event immediately.
+//
+// Speed can be passed before or after ReplayInput; both are plain
+// ProgramOptions applied in the order given to [NewProgram], and the speed
+// is only resolved against the replayer once [Program.Run] starts.
+func Speed(speed float64) ProgramOption {
+	return func(p *Program) {
+		p.replaySpeed = &speed
+	}
+}
@@ -0,0 +1,90 @@
+package tea
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// replayModel is a minimal Model that records the string representation of
+// every key it receives, so a test can assert on the final View.
+type replayModel struct {
+	keys []string
+}
+
+func (m replayModel) Init() Cmd { return nil }
+
+func (m replayModel) Update(msg Msg) (Model, Cmd) {
+	if k, ok := msg.(KeyPressMsg); ok {
+		m.keys = append(m.keys, Key(k).String())
+	}
+	return m, nil
+}
+
+func (m replayModel) View() string {
+	return strings.Join(m.keys, ",")
+}
+
+// TestReplayInputFixture replays a small recorded fixture end to end through
+// a real Program and asserts on the resulting View, the way a snapshot test
+// for a Bubble Tea app would.
+func TestReplayInputFixture(t *testing.T) {
+	const fixture = `{"at":0,"kind":"key_press","msg":{"key":"a","type":"press","mod":[]}}
+{"at":1000000,"kind":"key_press","msg":{"key":"ctrl+c","type":"press","mod":["ctrl"]}}
+`
+
+	p := NewProgram(
+		replayModel{},
+		ReplayInput(strings.NewReader(fixture)),
+		Speed(0), // don't wait out the fixture's recorded delays in the test
+	)
+
+	final, err := p.Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := "a,ctrl+c"
+	if got := final.View(); got != want {
+		t.Fatalf("View() = %q, want %q", got, want)
+	}
+}
+
+// TestReplayInputSendAfterRunDoesNotPanic is a regression test for a bug
+// where replay() closed the shared message channel once the recording was
+// exhausted, so a Cmd (or a caller of [Program.Send]) that delivered a
+// message afterward panicked with "send on closed channel". Nothing reads
+// from the channel once Run has returned, so the call is expected to block
+// rather than complete; what matters is that it never panics.
+func TestReplayInputSendAfterRunDoesNotPanic(t *testing.T) {
+	const fixture = `{"at":0,"kind":"key_press","msg":{"key":"a","type":"press","mod":[]}}
+`
+
+	p := NewProgram(
+		replayModel{},
+		ReplayInput(strings.NewReader(fixture)),
+		Speed(0),
+	)
+
+	if _, err := p.Run(); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	panicked := make(chan any, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				panicked <- r
+			}
+		}()
+		p.Send(KeyPressMsg{Type: KeyRunes, Runes: []rune{'b'}})
+	}()
+
+	select {
+	case r := <-panicked:
+		t.Fatalf("Send after Run returned panicked: %v", r)
+	case <-time.After(100 * time.Millisecond):
+		// No panic within the window; Send is blocked on the unread
+		// channel, as expected.
+	}
+}
@@ -0,0 +1,258 @@
+// Package tea provides a framework for building rich, terminal-based
+// applications using the Bubble Tea architecture, a variant of The Elm
+// Architecture.
+package tea
+
+import (
+	"io"
+	"time"
+)
+
+// Msg represents an asynchronous action, such as a key press, mouse event,
+// window resize, or the result of a Cmd. Update reacts to these to produce
+// the program's next state.
+type Msg interface{}
+
+// Cmd is an I/O operation that Update can trigger by returning one. It runs
+// in the background; whatever Msg it returns is fed back into the running
+// Program.
+type Cmd func() Msg
+
+// Model is the interface a Bubble Tea program implements: Init kicks things
+// off, Update reacts to each incoming Msg, and View renders the current
+// state.
+type Model interface {
+	Init() Cmd
+	Update(Msg) (Model, Cmd)
+	View() string
+}
+
+// KeyMod is a bitmask of modifier keys, such as ctrl or shift, held
+// alongside a key or mouse event.
+type KeyMod int
+
+// Modifier keys. These combine, e.g. ModCtrl|ModShift.
+const (
+	ModShift KeyMod = 1 << iota
+	ModAlt
+	ModCtrl
+	ModMeta
+	ModHyper
+	ModSuper
+	ModCapsLock
+	ModNumLock
+)
+
+// Contains reports whether mod is set within k.
+func (k KeyMod) Contains(mod KeyMod) bool {
+	return mod != 0 && k&mod == mod
+}
+
+// WindowSizeMsg is sent when the terminal window is resized.
+type WindowSizeMsg struct {
+	Width  int
+	Height int
+}
+
+// MouseButton identifies which button a mouse message refers to.
+type MouseButton int
+
+// Mouse buttons.
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseWheelUp
+	MouseWheelDown
+)
+
+// Mouse contains the fields shared by every mouse message.
+type Mouse struct {
+	X, Y   int
+	Button MouseButton
+	Mod    KeyMod
+}
+
+// MouseClickMsg is sent when a mouse button is pressed.
+type MouseClickMsg Mouse
+
+// MouseReleaseMsg is sent when a mouse button is released.
+type MouseReleaseMsg Mouse
+
+// MouseWheelMsg is sent on a mouse wheel event.
+type MouseWheelMsg Mouse
+
+// MouseMotionMsg is sent when the mouse moves, typically while a button is
+// held or when motion reporting is enabled.
+type MouseMotionMsg Mouse
+
+// ProgramOption configures a [Program] at construction time, such as
+// [WithComposeKey] or [RecordInput].
+type ProgramOption func(*Program)
+
+// Program runs a Bubble Tea [Model]: it dispatches incoming messages to
+// Update and renders the result of View.
+type Program struct {
+	model Model
+	msgs  chan Msg
+	done  chan struct{}
+
+	keyLayout *KeyLayout
+	keyRepeat *keyRepeatDetector
+
+	composeKey     *composeProcessor
+	composeFileErr error
+
+	recordTo    io.Writer
+	recorder    *recorder
+	replayFrom  *replayer
+	replaySpeed *float64
+}
+
+// NewProgram creates a Program for model, applying opts. Synthetic key
+// repeat detection (see [WithSyntheticKeyRepeat]) is enabled by default
+// with [DefaultSyntheticKeyRepeatMin] and [DefaultSyntheticKeyRepeatMax];
+// pass [WithoutSyntheticKeyRepeat] to disable it.
+func NewProgram(model Model, opts ...ProgramOption) *Program {
+	p := &Program{
+		model:     model,
+		msgs:      make(chan Msg),
+		done:      make(chan struct{}),
+		keyRepeat: newKeyRepeatDetector(DefaultSyntheticKeyRepeatMin, DefaultSyntheticKeyRepeatMax),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Send delivers msg to the running program, as if it had arrived from the
+// terminal.
+func (p *Program) Send(msg Msg) {
+	p.msgs <- msg
+}
+
+// RegisterCompose adds or overrides a two-key composition for the
+// program's compose processor (see [WithComposeKey]), such that pressing
+// the compose trigger followed by a then b produces out. It is a no-op if
+// compose processing hasn't been enabled.
+func (p *Program) RegisterCompose(a, b rune, out rune) {
+	if p.composeKey == nil {
+		return
+	}
+	p.composeKey.RegisterCompose(a, b, out)
+}
+
+// receive applies the input processing common to every message before it
+// reaches Update — layout translation, compose-sequence accumulation, and
+// so on — and reports the message Update should actually see. It returns
+// nil if msg was fully consumed, e.g. swallowed mid-compose-sequence.
+func (p *Program) receive(msg Msg) Msg {
+	km, ok := msg.(KeyPressMsg)
+	if !ok {
+		return msg
+	}
+
+	if p.keyLayout != nil {
+		k := Key(km)
+		if r, t := p.keyLayout.Lookup(k.scancode, k.Mod); r != 0 || t != KeyRunes {
+			if r != 0 {
+				k.baseRune = r
+			}
+			if t != KeyRunes {
+				k.Type = t
+			}
+			km = KeyPressMsg(k)
+		}
+	}
+
+	if p.keyRepeat != nil {
+		km = p.keyRepeat.process(km, time.Now())
+	}
+
+	if p.composeKey != nil {
+		out, consumed := p.composeKey.feed(km)
+		if consumed {
+			return out
+		}
+	}
+
+	return km
+}
+
+// Run starts the program's event loop: it drives Init, dispatches messages
+// to Update, and returns the final Model once the program is done. If
+// [ReplayInput] was used, Run returns once the recording is exhausted;
+// otherwise it blocks until something else signals completion.
+//
+// Run never closes its internal message channel, so [Program.Send] and the
+// Cmds it starts never panic with a send on a closed channel. Once Run has
+// returned nothing receives from that channel, and such a send blocks.
+func (p *Program) Run() (Model, error) {
+	if p.recordTo != nil {
+		p.recorder = newRecorder(p.recordTo, time.Now())
+	}
+	if p.replayFrom != nil {
+		if p.replaySpeed != nil {
+			p.replayFrom.setSpeed(*p.replaySpeed)
+		}
+		go p.replay()
+	}
+
+	model := p.model
+	if cmd := model.Init(); cmd != nil {
+		go p.exec(cmd)
+	}
+
+	for {
+		select {
+		case msg := <-p.msgs:
+			if p.recorder != nil {
+				_ = p.recorder.record(msg, time.Now())
+			}
+			m := p.receive(msg)
+			if m == nil {
+				continue
+			}
+			var cmd Cmd
+			model, cmd = model.Update(m)
+			if cmd != nil {
+				go p.exec(cmd)
+			}
+		case <-p.done:
+			return model, nil
+		}
+	}
+}
+
+func (p *Program) exec(cmd Cmd) {
+	if msg := cmd(); msg != nil {
+		p.msgs <- msg
+	}
+}
+
+// replay feeds messages decoded from p.replayFrom into the program,
+// honoring each event's original inter-arrival delay, and signals
+// [Program.done] once the recording is exhausted. It never closes p.msgs:
+// since that channel is unbuffered, the final send here only returns once
+// Run has received it, so signaling done afterward can't race a send on a
+// closed channel from Run, Send, or a Cmd started by exec.
+func (p *Program) replay() {
+	start := time.Now()
+	for {
+		ev, err := p.replayFrom.next()
+		if err != nil {
+			close(p.done)
+			return
+		}
+		if d := p.replayFrom.delay(ev, time.Since(start)); d > 0 {
+			time.Sleep(d)
+		}
+		msg, err := decodeMsg(ev)
+		if err != nil {
+			continue
+		}
+		p.msgs <- msg
+	}
+}